@@ -0,0 +1,87 @@
+package metrics
+
+import "fmt"
+
+// BackendType selects which source NewClient builds its Metrics implementation against.
+type BackendType string
+
+const (
+	// OCPBackend discovers and queries the in-cluster OpenShift monitoring Prometheus.
+	// This is the default when no BackendConfig is supplied, preserving existing behaviour.
+	OCPBackend BackendType = "OCP"
+	// ThanosBackend queries a user-supplied Thanos/Prometheus endpoint, e.g. a federated
+	// store used by clusters that do not expose their own in-cluster Prometheus route.
+	ThanosBackend BackendType = "Thanos"
+	// InProcessBackend answers queries from the operator's own Prometheus registry and the
+	// cluster API, for environments where no Prometheus route is reachable at all (HyperShift,
+	// disconnected test clusters).
+	InProcessBackend BackendType = "InProcess"
+)
+
+// BackendConfig selects and configures the Metrics backend that NewClient builds. It is
+// intended to be embedded in the operator's config CR so the backend can be switched without
+// a rebuild. Exactly one of OCP, Thanos or InProcess is consulted, chosen by Type.
+type BackendConfig struct {
+	Type BackendType `json:"type"`
+
+	OCP       *OCPBackendConfig       `json:"ocp,omitempty"`
+	Thanos    *ThanosBackendConfig    `json:"thanos,omitempty"`
+	InProcess *InProcessBackendConfig `json:"inProcess,omitempty"`
+}
+
+// OCPBackendConfig configures discovery of the in-cluster OpenShift monitoring stack.
+type OCPBackendConfig struct {
+	// ServiceAccountName is the operator's own ServiceAccount that a TokenRequest token is
+	// issued for, scoped to the prometheus-k8s audience and verified by kube-rbac-proxy in
+	// front of Prometheus. It is not prometheus-k8s's own ServiceAccount - the operator does
+	// not mint tokens for another component's identity. Defaults to managed-upgrade-operator
+	// when empty.
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// UseRoute forces endpoint resolution via the openshift-monitoring Route rather than the
+	// in-cluster thanos-querier/prometheus-k8s Service. Only needed where the Service is not
+	// reachable from the operator's pod network.
+	UseRoute bool `json:"useRoute,omitempty"`
+}
+
+// ThanosBackendConfig points the operator at an externally managed Thanos/Prometheus, as is
+// common in federated setups where the in-cluster route is not appropriate to query directly.
+type ThanosBackendConfig struct {
+	// URL is the base URL of the Thanos/Prometheus HTTP API, e.g.
+	// https://thanos-querier.example.com; queries are sent to URL + "/api/v1/query".
+	URL string `json:"url"`
+	// BearerTokenFile is a path to a bearer token presented on every request.
+	BearerTokenFile string `json:"bearerTokenFile,omitempty"`
+	// CAFile is the CA bundle trusted for URL and may be set on its own. ClientCertFile and
+	// ClientKeyFile configure the client certificate for mTLS against URL and must be set
+	// together, or not at all.
+	CAFile         string `json:"caFile,omitempty"`
+	ClientCertFile string `json:"clientCertFile,omitempty"`
+	ClientKeyFile  string `json:"clientKeyFile,omitempty"`
+	// PartialResponse and Dedup send the Thanos query API's partial_response=true/dedup=true
+	// parameters when set. When false the parameter is omitted and the server's default applies.
+	PartialResponse bool `json:"partialResponse,omitempty"`
+	Dedup           bool `json:"dedup,omitempty"`
+}
+
+// InProcessBackendConfig has no fields of its own today; it exists so BackendConfig.Type can
+// select the in-process path explicitly.
+type InProcessBackendConfig struct{}
+
+func (bc BackendConfig) validate() error {
+	switch bc.Type {
+	case "", OCPBackend:
+		return nil
+	case ThanosBackend:
+		if bc.Thanos == nil || bc.Thanos.URL == "" {
+			return fmt.Errorf("metrics backend %q requires a url", ThanosBackend)
+		}
+		if (bc.Thanos.ClientCertFile == "") != (bc.Thanos.ClientKeyFile == "") {
+			return fmt.Errorf("metrics backend %q requires both clientCertFile and clientKeyFile, or neither", ThanosBackend)
+		}
+		return nil
+	case InProcessBackend:
+		return nil
+	default:
+		return fmt.Errorf("unknown metrics backend type %q", bc.Type)
+	}
+}
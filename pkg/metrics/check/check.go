@@ -0,0 +1,222 @@
+// Package check implements declarative, Prometheus-backed gates meant to be used by the
+// upgrade state machine's pre-upgrade and post-upgrade phases (an UpgradeConfig's
+// `prometheusChecks:` list).
+//
+// Nothing in this repo slice wires CheckProvider/RunNamed into those phases yet - there is no
+// upgrade state machine here to call them, so they have no caller outside this package today.
+// This package provides the primitives; hooking them into prometheusChecks processing is left
+// to that machine.
+package check
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// Operator is the comparison a CheckCondition applies to a PromQL result.
+type Operator string
+
+const (
+	OpGreaterThan Operator = ">"
+	OpLessThan    Operator = "<"
+	OpEqual       Operator = "=="
+	OpNotEqual    Operator = "!="
+	OpAbsent      Operator = "absent"
+	OpPresent     Operator = "present"
+)
+
+// CheckCondition describes the pass criterion for a PromQL query: a comparison against a
+// scalar/vector result, held for the full For duration before passing.
+type CheckCondition struct {
+	// Op is the comparison to apply. OpAbsent/OpPresent ignore Value and only look at whether
+	// the query returned any series.
+	Op Operator
+	// Value is the scalar compared against the query's result when Op is >, <, == or !=.
+	Value float64
+	// For is how long the condition must hold continuously before the check passes. Zero means
+	// a single sample is sufficient.
+	For time.Duration
+	// Timeout bounds how long PromQL keeps polling before giving up and returning the last
+	// observed result as failed. Zero means no deadline: PromQL polls until the condition has
+	// held for For.
+	Timeout time.Duration
+	// Interval is how often PromQL re-samples while polling. Defaults to 30s.
+	Interval time.Duration
+}
+
+// CheckResult is the outcome of evaluating a CheckCondition.
+type CheckResult struct {
+	Passed bool
+	// LastValue is the most recently observed scalar value, when the query returned one.
+	LastValue float64
+	// Message explains why the check did or didn't pass, for logging/status surfacing.
+	Message string
+}
+
+//go:generate mockgen -destination=mocks/check.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/metrics/check CheckProvider
+type CheckProvider interface {
+	PromQL(query string, condition CheckCondition) (CheckResult, error)
+	AlertAbsent(alert string, forDuration time.Duration) (CheckResult, error)
+}
+
+func NewCheckProvider(m metrics.Metrics) CheckProvider {
+	return &checkProvider{metrics: m}
+}
+
+type checkProvider struct {
+	metrics metrics.Metrics
+}
+
+func (cp *checkProvider) PromQL(query string, condition CheckCondition) (CheckResult, error) {
+	interval := condition.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	// A zero Timeout does not mean "one sample and done" - it means poll with no deadline
+	// until the For hold is satisfied. Without this, For is unreachable through any caller
+	// that doesn't also set a Timeout.
+	var deadline time.Time
+	if condition.Timeout > 0 {
+		deadline = time.Now().Add(condition.Timeout)
+	}
+	var holdSince time.Time
+
+	for {
+		resp, err := cp.metrics.Query(query)
+		if err != nil {
+			return CheckResult{}, fmt.Errorf("prometheus check query failed: %w", err)
+		}
+
+		result, passed := evaluate(resp, condition)
+		if passed {
+			if holdSince.IsZero() {
+				holdSince = time.Now()
+			}
+			if time.Since(holdSince) >= condition.For {
+				return result, nil
+			}
+		} else {
+			holdSince = time.Time{}
+		}
+
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			if passed {
+				// The condition holds now but has not held for the full For window.
+				result.Passed = false
+				result.Message = "condition did not hold for the full For duration before the timeout"
+			}
+			return result, nil
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+// AlertAbsent is the only CheckProvider method InProcessBackend can answer: its query is
+// exactly the shape metrics.inProcessCounter.Query recognizes. PromQL with an arbitrary query
+// string - i.e. any NamedCheck - needs a real Prometheus (OCPBackend or ThanosBackend).
+func (cp *checkProvider) AlertAbsent(alert string, forDuration time.Duration) (CheckResult, error) {
+	return cp.PromQL(fmt.Sprintf(`ALERTS{alertname="%s",alertstate="firing"}`, alert), CheckCondition{
+		Op:  OpAbsent,
+		For: forDuration,
+		// Bound the otherwise-unbounded poll (see PromQL) at twice the hold window, so a
+		// permanently-firing alert fails the gate instead of blocking the caller forever. A zero
+		// forDuration gives a zero Timeout, which leaves the poll without a deadline.
+		Timeout: forDuration * 2,
+	})
+}
+
+func evaluate(resp *metrics.AlertResponse, condition CheckCondition) (CheckResult, bool) {
+	switch condition.Op {
+	case OpAbsent:
+		passed := len(resp.Data.Result) == 0
+		return CheckResult{Passed: passed}, passed
+	case OpPresent:
+		passed := len(resp.Data.Result) > 0
+		return CheckResult{Passed: passed}, passed
+	}
+
+	if len(resp.Data.Result) == 0 {
+		return CheckResult{Passed: false, Message: "query returned no series"}, false
+	}
+
+	value, err := scalarValue(resp.Data.Result[0])
+	if err != nil {
+		return CheckResult{Passed: false, Message: err.Error()}, false
+	}
+
+	var passed bool
+	switch condition.Op {
+	case OpGreaterThan:
+		passed = value > condition.Value
+	case OpLessThan:
+		passed = value < condition.Value
+	case OpEqual:
+		passed = value == condition.Value
+	case OpNotEqual:
+		passed = value != condition.Value
+	}
+
+	return CheckResult{Passed: passed, LastValue: value}, passed
+}
+
+func scalarValue(r metrics.AlertResult) (float64, error) {
+	if len(r.Value) != 2 {
+		return 0, fmt.Errorf("unexpected prometheus vector sample shape")
+	}
+	s, ok := r.Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected prometheus vector sample value type")
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+var metricPrometheusCheck = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	// No Subsystem here, deliberately: the spec asks for upgradeoperator_prometheus_check,
+	// unlike postupgrade_critical_alerts which does carry the "upgrade" subsystem.
+	Namespace: metrics.Namespace,
+	Name:      "prometheus_check",
+	Help:      "Outcome of a declarative prometheusChecks entry (1 = passed, 0 = failed)",
+}, []string{"name", "phase", "cluster_id"})
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(metricPrometheusCheck)
+}
+
+// NamedCheck pairs a declarative prometheusChecks entry (as set on an UpgradeConfig) with the
+// phase it gates, so its outcome can be recorded against metricPrometheusCheck.
+type NamedCheck struct {
+	Name      string
+	Phase     string
+	Query     string
+	Condition CheckCondition
+}
+
+// RunNamed runs a NamedCheck and records its pass/fail outcome against metricPrometheusCheck,
+// labelled with the given clusterID (normally the backing client's metrics.Metrics.ClusterID).
+// It runs one configured prometheusChecks entry; see the package doc for who calls it.
+func RunNamed(cp CheckProvider, clusterID string, check NamedCheck) (CheckResult, error) {
+	result, err := cp.PromQL(check.Query, check.Condition)
+	if err != nil {
+		return result, err
+	}
+
+	value := float64(0)
+	if result.Passed {
+		value = 1
+	}
+	metricPrometheusCheck.With(prometheus.Labels{
+		"name":       check.Name,
+		"phase":      check.Phase,
+		"cluster_id": clusterID,
+	}).Set(value)
+
+	return result, nil
+}
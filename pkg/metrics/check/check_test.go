@@ -0,0 +1,93 @@
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openshift/managed-upgrade-operator/pkg/metrics"
+)
+
+// sequencedMetrics is a fake metrics.Metrics that replays a fixed sequence of Query responses,
+// repeating the last one once exhausted, so a test can flip a condition's pass/fail state
+// partway through a PromQL poll loop.
+type sequencedMetrics struct {
+	metrics.Metrics
+	responses []*metrics.AlertResponse
+	calls     int
+}
+
+func (s *sequencedMetrics) Query(query string) (*metrics.AlertResponse, error) {
+	resp := s.responses[s.calls]
+	if s.calls < len(s.responses)-1 {
+		s.calls++
+	}
+	return resp, nil
+}
+
+func firingAlert() *metrics.AlertResponse {
+	return &metrics.AlertResponse{
+		Data: metrics.AlertData{
+			Result: []metrics.AlertResult{{Metric: map[string]string{"alertname": "Foo"}}},
+		},
+	}
+}
+
+func absentAlert() *metrics.AlertResponse {
+	return &metrics.AlertResponse{}
+}
+
+// TestPromQLHoldsForDurationBeforePassing guards against regressing to a single-sample check:
+// the condition must stay absent for the full For window, across several polls, before
+// PromQL reports it passed.
+func TestPromQLHoldsForDurationBeforePassing(t *testing.T) {
+	fake := &sequencedMetrics{responses: []*metrics.AlertResponse{
+		firingAlert(),
+		absentAlert(),
+		absentAlert(),
+		absentAlert(),
+	}}
+	cp := NewCheckProvider(fake)
+
+	result, err := cp.PromQL(`ALERTS{alertname="Foo",alertstate="firing"}`, CheckCondition{
+		Op:       OpAbsent,
+		For:      150 * time.Millisecond,
+		Interval: 50 * time.Millisecond,
+		Timeout:  time.Second,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Passed {
+		t.Fatalf("expected check to pass once the alert stayed absent for the full For window")
+	}
+	if fake.calls < 3 {
+		t.Fatalf("expected PromQL to poll more than once to honour For, got %d calls", fake.calls)
+	}
+}
+
+// TestPromQLZeroTimeoutStillHoldsFor is the regression test for the bug where Timeout == 0
+// caused PromQL to return after a single sample regardless of For, making the hold guarantee
+// unreachable through any caller that left Timeout unset.
+func TestPromQLZeroTimeoutStillHoldsFor(t *testing.T) {
+	fake := &sequencedMetrics{responses: []*metrics.AlertResponse{
+		absentAlert(),
+		absentAlert(),
+		absentAlert(),
+	}}
+	cp := NewCheckProvider(fake)
+
+	result, err := cp.PromQL(`ALERTS{alertname="Foo",alertstate="firing"}`, CheckCondition{
+		Op:       OpAbsent,
+		For:      100 * time.Millisecond,
+		Interval: 30 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Passed {
+		t.Fatalf("expected check to pass after holding absent for the For window with no Timeout set")
+	}
+	if fake.calls < 2 {
+		t.Fatalf("expected PromQL to poll more than once with Timeout == 0, got %d calls", fake.calls)
+	}
+}
@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// clusterIDEnvOverride lets HyperShift hosted clusters, where the management cluster's
+// ClusterVersion UID is not the billing cluster ID, supply the fleet cluster_id explicitly.
+const clusterIDEnvOverride = "MUO_CLUSTER_ID"
+
+// resolveClusterID is called once per NewClient call to determine the cluster_id label value
+// stamped onto every metric emitted by the returned Metrics client.
+func resolveClusterID(c client.Client) (string, error) {
+	if override := os.Getenv(clusterIDEnvOverride); override != "" {
+		return override, nil
+	}
+
+	cv := &configv1.ClusterVersion{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "version"}, cv); err != nil {
+		return "", fmt.Errorf("%w: %s", ErrClusterIDNotFound, err)
+	}
+
+	return string(cv.Spec.ClusterID), nil
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import "errors"
+
+// Sentinel errors returned by the metrics package's Prometheus clients, so callers can branch
+// on failure mode with errors.Is instead of string-matching error messages.
+var (
+	// ErrPromRouteNotFound is returned when the Prometheus/Thanos endpoint (Route or Service)
+	// could not be resolved. Typically transient - the monitoring stack may still be rolling out.
+	ErrPromRouteNotFound = errors.New("prometheus endpoint not found")
+	// ErrPromTokenNotFound is returned when a Prometheus access token could not be obtained.
+	ErrPromTokenNotFound = errors.New("prometheus token not found")
+	// ErrPromQuery is returned when a query request to Prometheus could not be made or failed.
+	ErrPromQuery = errors.New("prometheus query failed")
+	// ErrPromDecode is returned when a Prometheus response could not be read or decoded.
+	ErrPromDecode = errors.New("could not decode prometheus response")
+	// ErrClusterIDNotFound is returned when the fleet cluster_id label value could not be
+	// resolved from ClusterVersion (and no MUO_CLUSTER_ID override was set).
+	ErrClusterIDNotFound = errors.New("cluster_id not found")
+	// ErrServingCANotFound is returned when the cluster's serving CA bundle could not be
+	// loaded from the openshift-service-ca.crt ConfigMap.
+	ErrServingCANotFound = errors.New("serving CA bundle not found")
+)
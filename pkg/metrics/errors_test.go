@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// erroringRoundTripper always fails the request, to exercise Query's transport-error path.
+type erroringRoundTripper struct{}
+
+func (erroringRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+// badJSONRoundTripper returns a 200 with a body that isn't valid JSON, to exercise Query's
+// decode-error path.
+type badJSONRoundTripper struct{}
+
+func (badJSONRoundTripper) RoundTrip(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader("not json")),
+	}, nil
+}
+
+func TestQueryWrapsTransportErrorAsErrPromQuery(t *testing.T) {
+	c := &Counter{
+		promHost:   "http://prometheus.example.com",
+		promClient: http.Client{Transport: erroringRoundTripper{}},
+	}
+
+	_, err := c.Query("up")
+	if !errors.Is(err, ErrPromQuery) {
+		t.Fatalf("expected error to match ErrPromQuery, got %v", err)
+	}
+}
+
+func TestGetFiringCriticalAlertsWrapsQueryErrorAsErrPromQuery(t *testing.T) {
+	c := &Counter{
+		promHost:   "http://prometheus.example.com",
+		promClient: http.Client{Transport: erroringRoundTripper{}},
+	}
+
+	_, err := c.GetFiringCriticalAlerts(0, nil)
+	if !errors.Is(err, ErrPromQuery) {
+		t.Fatalf("expected error to match ErrPromQuery, got %v", err)
+	}
+}
+
+func TestQueryWrapsDecodeErrorAsErrPromDecode(t *testing.T) {
+	c := &Counter{
+		promHost:   "http://prometheus.example.com",
+		promClient: http.Client{Transport: badJSONRoundTripper{}},
+	}
+
+	_, err := c.Query("up")
+	if !errors.Is(err, ErrPromDecode) {
+		t.Fatalf("expected error to match ErrPromDecode, got %v", err)
+	}
+}
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("could not register client-go scheme: %v", err)
+	}
+	if err := configv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("could not register config/v1 scheme: %v", err)
+	}
+	return scheme
+}
+
+func TestResolveClusterIDWrapsMissingClusterVersionAsErrClusterIDNotFound(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+
+	_, err := resolveClusterID(c)
+	if !errors.Is(err, ErrClusterIDNotFound) {
+		t.Fatalf("expected error to match ErrClusterIDNotFound, got %v", err)
+	}
+}
+
+func TestGetServingCAPoolWrapsMissingConfigMapAsErrServingCANotFound(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+
+	_, err := getServingCAPool(c)
+	if !errors.Is(err, ErrServingCANotFound) {
+		t.Fatalf("expected error to match ErrServingCANotFound, got %v", err)
+	}
+}
+
+func TestGetServingCAPoolWrapsMissingKeyAsErrServingCANotFound(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: servingCAConfigMapName, Namespace: monitoringNamespace},
+		Data:       map[string]string{"unrelated-key": "value"},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(cm).Build()
+
+	_, err := getServingCAPool(c)
+	if !errors.Is(err, ErrServingCANotFound) {
+		t.Fatalf("expected error to match ErrServingCANotFound, got %v", err)
+	}
+}
+
+func TestGetPromHostWrapsMissingServiceAsErrPromRouteNotFound(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).Build()
+
+	_, err := getPromHost(c, OCPBackendConfig{})
+	if !errors.Is(err, ErrPromRouteNotFound) {
+		t.Fatalf("expected error to match ErrPromRouteNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	configv1 "github.com/openshift/api/config/v1"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// alertFiringQueryPattern matches the one query shape pkg/metrics/check.AlertAbsent issues
+// against Metrics.Query. It's the only PromQL this backend can answer (see Query below).
+var alertFiringQueryPattern = regexp.MustCompile(`^ALERTS\{alertname="([^"]*)",alertstate="firing"\}$`)
+
+// newInProcessClient builds a Metrics implementation that never leaves the operator's own
+// process. Writes land on the same local prometheus.Registry gauges every backend already
+// writes to; reads are answered from that registry and from lookups against c, instead of an
+// HTTP query against an external Prometheus. This lets the operator run where no in-cluster
+// Prometheus route is reachable at all, e.g. HyperShift hosted clusters or disconnected test
+// clusters.
+func newInProcessClient(c client.Client, clusterID string) Metrics {
+	return &inProcessCounter{Counter: Counter{clusterID: clusterID}, client: c}
+}
+
+type inProcessCounter struct {
+	Counter
+	client client.Client
+}
+
+// Query does not implement arbitrary PromQL - there's no local equivalent without embedding a
+// query engine - but it does recognize the one query shape pkg/metrics/check.AlertAbsent
+// issues (an ALERTS{alertname="...",alertstate="firing"} selector with no other labels), and
+// answers it via the same PrometheusRule-based approximation IsAlertFiring uses below. That
+// makes pkg/metrics/check.CheckProvider usable against InProcessBackend for AlertAbsent checks;
+// any other query - including NamedCheck entries with a custom PromQL expression - returns an
+// error, since this backend genuinely cannot evaluate them.
+func (c *inProcessCounter) Query(query string) (*AlertResponse, error) {
+	if m := alertFiringQueryPattern.FindStringSubmatch(query); m != nil {
+		return c.alertFiringResponse(m[1])
+	}
+
+	return nil, fmt.Errorf("in-process backend only supports ALERTS{alertname=\"...\",alertstate=\"firing\"} queries, got: %s", query)
+}
+
+func (c *inProcessCounter) alertFiringResponse(alert string) (*AlertResponse, error) {
+	firing, err := c.IsAlertFiring(alert, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !firing {
+		return &AlertResponse{}, nil
+	}
+	return &AlertResponse{
+		Data: AlertData{Result: []AlertResult{{Metric: map[string]string{alertNameLabel: alert}}}},
+	}, nil
+}
+
+func (c *inProcessCounter) IsClusterVersionAtVersion(version string) (bool, error) {
+	cv := &configv1.ClusterVersion{}
+	if err := c.client.Get(context.TODO(), types.NamespacedName{Name: "version"}, cv); err != nil {
+		return false, fmt.Errorf("could not fetch ClusterVersion: %s", err)
+	}
+
+	for _, h := range cv.Status.History {
+		if h.State == configv1.CompletedUpdate && h.Version == version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// IsAlertFiring has no local source of Alertmanager's firing state to consult, so the
+// in-process backend approximates it by checking whether `alert` is still defined by a
+// PrometheusRule CR in checkedNS: a rule that's been removed can't be firing, but a rule that
+// exists may or may not actually be evaluating true right now. This over-approximates real
+// firing state: every defined rule is reported as firing; callers that need the exact answer should configure a ThanosBackend or
+// OCPBackend instead.
+// TODO(managed-upgrade-operator): track whether Alertmanager's firing state can be surfaced
+// through a CR (e.g. AlertmanagerConfig status) so this can become exact without requiring an
+// external Prometheus/Alertmanager call.
+func (c *inProcessCounter) IsAlertFiring(alert string, checkedNS, ignoredNS []string) (bool, error) {
+	rules, err := c.listPrometheusRules()
+	if err != nil {
+		return false, err
+	}
+
+	ignored := toSet(ignoredNS)
+	checked := toSet(checkedNS)
+
+	for _, rule := range rules.Items {
+		if ignored[rule.Namespace] {
+			continue
+		}
+		if len(checked) > 0 && !checked[rule.Namespace] {
+			continue
+		}
+		for _, group := range rule.Spec.Groups {
+			for _, r := range group.Rules {
+				if r.Alert == alert {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// GetFiringCriticalAlerts has the same limitation as IsAlertFiring: with no local source of
+// Alertmanager's firing state, the in-process backend reports every severity=critical alerting
+// rule still defined by a PrometheusRule CR as a candidate, rather than confirming it is
+// actually firing. See the TODO on IsAlertFiring.
+func (c *inProcessCounter) GetFiringCriticalAlerts(window time.Duration, ignore []string) ([]FiringAlert, error) {
+	rules, err := c.listPrometheusRules()
+	if err != nil {
+		return nil, err
+	}
+
+	ignored := toSet(ignore)
+
+	var alerts []FiringAlert
+	for _, rule := range rules.Items {
+		for _, group := range rule.Spec.Groups {
+			for _, r := range group.Rules {
+				if r.Alert == "" || ignored[r.Alert] {
+					continue
+				}
+				if r.Labels["severity"] != "critical" {
+					continue
+				}
+				alerts = append(alerts, FiringAlert{Name: r.Alert, Labels: r.Labels})
+			}
+		}
+	}
+
+	return alerts, nil
+}
+
+func (c *inProcessCounter) listPrometheusRules() (*monitoringv1.PrometheusRuleList, error) {
+	rules := &monitoringv1.PrometheusRuleList{}
+	if err := c.client.List(context.TODO(), rules); err != nil {
+		return nil, fmt.Errorf("could not list PrometheusRule objects: %s", err)
+	}
+	return rules, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func (c *inProcessCounter) IsMetricNotificationEventSentSet(upgradeConfigName string, event string, version string) (bool, error) {
+	families, err := ctrlmetrics.Registry.Gather()
+	if err != nil {
+		return false, fmt.Errorf("could not gather local metrics registry: %s", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != fmt.Sprintf("%s_upgrade_notification", NamespaceV2) {
+			continue
+		}
+		for _, m := range family.Metric {
+			if metricMatches(m, map[string]string{
+				nameLabel:      upgradeConfigName,
+				eventLabel:     event,
+				VersionLabel:   version,
+				clusterIDLabel: c.clusterID,
+			}) && m.GetGauge().GetValue() == 1 {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func metricMatches(m *dto.Metric, want map[string]string) bool {
+	have := make(map[string]string, len(m.Label))
+	for _, l := range m.Label {
+		have[l.GetName()] = l.GetValue()
+	}
+	for k, v := range want {
+		if have[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"testing"
+
+	monitoringv1 "github.com/coreos/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newInProcessTestClient(t *testing.T, objs ...client.Object) *inProcessCounter {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := monitoringv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("could not register monitoring/v1 scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return newInProcessClient(c, "test-cluster").(*inProcessCounter)
+}
+
+func prometheusRuleWithAlert(name, alertName string) *monitoringv1.PrometheusRule {
+	return &monitoringv1.PrometheusRule{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "openshift-monitoring"},
+		Spec: monitoringv1.PrometheusRuleSpec{
+			Groups: []monitoringv1.RuleGroup{{
+				Name:  "test",
+				Rules: []monitoringv1.Rule{{Alert: alertName}},
+			}},
+		},
+	}
+}
+
+func TestInProcessQueryRecognizesAlertFiringShape(t *testing.T) {
+	c := newInProcessTestClient(t, prometheusRuleWithAlert("test-rule", "Foo"))
+
+	resp, err := c.Query(`ALERTS{alertname="Foo",alertstate="firing"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data.Result) != 1 {
+		t.Fatalf("expected one result for a rule that exists, got %d", len(resp.Data.Result))
+	}
+}
+
+func TestInProcessQueryRecognizesAlertAbsentShape(t *testing.T) {
+	c := newInProcessTestClient(t)
+
+	resp, err := c.Query(`ALERTS{alertname="Foo",alertstate="firing"}`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Data.Result) != 0 {
+		t.Fatalf("expected no results when no matching PrometheusRule exists, got %d", len(resp.Data.Result))
+	}
+}
+
+func TestInProcessQueryRejectsUnsupportedShapes(t *testing.T) {
+	c := newInProcessTestClient(t)
+
+	if _, err := c.Query(`up`); err == nil {
+		t.Fatalf("expected an error for a query shape this backend cannot evaluate")
+	}
+}
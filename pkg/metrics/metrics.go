@@ -1,7 +1,6 @@
 package metrics
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -9,23 +8,27 @@ import (
 	"strings"
 	"time"
 
-	routev1 "github.com/openshift/api/route/v1"
 	"github.com/prometheus/client_golang/prometheus"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
 const (
-	eventLabel = "event"
-	metricsTag = "upgradeoperator"
-	nameLabel  = "upgradeconfig_name"
-	nodeLabel  = "node_name"
+	alertNameLabel = "alertname"
+	clusterIDLabel = "cluster_id"
+	eventLabel     = "event"
+	metricsTag     = "upgradeoperator"
+	nameLabel      = "upgradeconfig_name"
+	nodeLabel      = "node_name"
 
 	Namespace = "upgradeoperator"
 	Subsystem = "upgrade"
 
+	// NamespaceV2 is the namespace for the cluster_id-labelled generation of every metric that
+	// predates fleet-wide federation. It is double-written alongside the original, unlabelled
+	// series for one release so downstream dashboards can migrate explicitly; see setDual.
+	NamespaceV2 = "upgradeoperator_v2"
+
 	StateLabel   = "state"
 	VersionLabel = "version"
 
@@ -61,12 +64,23 @@ type Metrics interface {
 	UpdateMetricNodeDrainFailed(string)
 	ResetMetricNodeDrainFailed(string)
 	UpdateMetricNotificationEventSent(string, string, string)
+	UpdateMetricPostUpgradeCriticalAlert(upgradeConfigName, alertName string)
+	ResetMetricPostUpgradeCriticalAlerts(upgradeConfigName string)
+	GetFiringCriticalAlerts(window time.Duration, ignore []string) ([]FiringAlert, error)
 	IsAlertFiring(alert string, checkedNS, ignoredNS []string) (bool, error)
 	IsMetricNotificationEventSentSet(upgradeConfigName string, event string, version string) (bool, error)
 	IsClusterVersionAtVersion(version string) (bool, error)
 	Query(query string) (*AlertResponse, error)
 	ResetMetrics()
 	ResetAllMetricNodeDrainFailed()
+	// ClusterID returns the cluster_id label value stamped onto every metric this client
+	// emits, resolved once when the client was built. See BackendConfig and resolveClusterID.
+	ClusterID() string
+	// ReloadCA refreshes the serving CA this client trusts, for a caller (e.g. a ConfigMap
+	// watch on openshift-service-ca.crt) to invoke when it rotates, so the client doesn't need
+	// to be rebuilt. Backends that don't hold a CA pool (Thanos with a static caFile,
+	// InProcess) treat this as a no-op.
+	ReloadCA(c client.Client) error
 }
 
 //go:generate mockgen -destination=mocks/metrics_builder.go -package=mocks github.com/openshift/managed-upgrade-operator/pkg/metrics MetricsBuilder
@@ -74,48 +88,60 @@ type MetricsBuilder interface {
 	NewClient(c client.Client) (Metrics, error)
 }
 
-func NewBuilder() MetricsBuilder {
-	return &metricsBuilder{}
+// NewBuilder returns a MetricsBuilder for the given BackendConfig. An empty BackendConfig
+// (zero value) selects OCPBackend, preserving the historical default of discovering the
+// in-cluster OpenShift monitoring Prometheus.
+func NewBuilder(cfg BackendConfig) MetricsBuilder {
+	return &metricsBuilder{config: cfg}
 }
 
-type metricsBuilder struct{}
+type metricsBuilder struct {
+	config BackendConfig
+}
 
 func (mb *metricsBuilder) NewClient(c client.Client) (Metrics, error) {
-	promHost, err := getPromHost(c)
-	if err != nil {
+	if err := mb.config.validate(); err != nil {
 		return nil, err
 	}
 
-	token, err := getPrometheusToken(c)
+	clusterID, err := resolveClusterID(c)
 	if err != nil {
 		return nil, err
 	}
 
-	return &Counter{
-		promHost: *promHost,
-		promClient: http.Client{
-			Transport: &prometheusRoundTripper{
-				token: *token,
-			},
-		},
-	}, nil
+	switch mb.config.Type {
+	case ThanosBackend:
+		return newThanosClient(*mb.config.Thanos, clusterID)
+	case InProcessBackend:
+		return newInProcessClient(c, clusterID), nil
+	default:
+		ocpCfg := OCPBackendConfig{}
+		if mb.config.OCP != nil {
+			ocpCfg = *mb.config.OCP
+		}
+		return newOCPClient(c, ocpCfg, clusterID)
+	}
 }
 
-type prometheusRoundTripper struct {
-	token string
+type Counter struct {
+	promClient  http.Client
+	promHost    string
+	queryParams map[string]string
+	clusterID   string
 }
 
-func (prt *prometheusRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
-	req.Header.Add("Authorization", "Bearer "+prt.token)
-	transport := http.Transport{
-		TLSHandshakeTimeout: time.Second * 5,
-	}
-	return transport.RoundTrip(req)
+func (c *Counter) ClusterID() string {
+	return c.clusterID
 }
 
-type Counter struct {
-	promClient http.Client
-	promHost   string
+// ReloadCA refreshes the serving CA pool trusted by this Counter, if its transport maintains
+// one (the OCP backend's prometheusRoundTripper). Other backends are a no-op.
+func (c *Counter) ReloadCA(cl client.Client) error {
+	rt, ok := c.promClient.Transport.(*prometheusRoundTripper)
+	if !ok {
+		return nil
+	}
+	return rt.reload(cl)
 }
 
 var (
@@ -169,6 +195,67 @@ var (
 		Name:      "upgrade_notification",
 		Help:      "Notification event raised",
 	}, []string{nameLabel, eventLabel, VersionLabel})
+	metricPostUpgradeCriticalAlert = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: Subsystem,
+		Name:      "postupgrade_critical_alerts",
+		Help:      "Critical alert observed firing during the post-upgrade alert soak",
+	}, []string{nameLabel, alertNameLabel, clusterIDLabel})
+
+	// The V2 gauges below are the cluster_id-labelled generation of the metrics above them,
+	// double-written by setDual so a federating Prometheus/Thanos can tell clusters apart
+	// (the unlabelled upgradeconfig_name alone collides across a fleet). Downstream dashboards
+	// migrate to these explicitly; the originals are dropped in a later release.
+	metricValidationFailedV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "upgradeconfig_validation_failed",
+		Help:      "Failed to validate the upgrade config",
+	}, []string{nameLabel, clusterIDLabel})
+	metricClusterCheckFailedV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "cluster_check_failed",
+		Help:      "Failed on the cluster check step",
+	}, []string{nameLabel, clusterIDLabel})
+	metricScalingFailedV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "scaling_failed",
+		Help:      "Failed to scale up extra workers",
+	}, []string{nameLabel, clusterIDLabel})
+	metricClusterVerificationFailedV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "cluster_verification_failed",
+		Help:      "Failed on the cluster upgrade verification step",
+	}, []string{nameLabel, clusterIDLabel})
+	metricUpgradeWindowBreachedV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "upgrade_window_breached",
+		Help:      "Failed to commence upgrade during the upgrade window",
+	}, []string{nameLabel, clusterIDLabel})
+	metricUpgradeConfigSyncedV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "upgradeconfig_synced",
+		Help:      "UpgradeConfig has not been synced in time",
+	}, []string{nameLabel, clusterIDLabel})
+	metricUpgradeControlPlaneTimeoutV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "controlplane_timeout",
+		Help:      "Control plane upgrade timeout",
+	}, []string{nameLabel, VersionLabel, clusterIDLabel})
+	metricUpgradeWorkerTimeoutV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "worker_timeout",
+		Help:      "Worker nodes upgrade timeout",
+	}, []string{nameLabel, VersionLabel, clusterIDLabel})
+	metricNodeDrainFailedV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "node_drain_timeout",
+		Help:      "Node cannot be drained successfully in time.",
+	}, []string{nodeLabel, clusterIDLabel})
+	metricUpgradeNotificationV2 = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: NamespaceV2,
+		Name:      "upgrade_notification",
+		Help:      "Notification event raised",
+	}, []string{nameLabel, eventLabel, VersionLabel, clusterIDLabel})
 
 	metricsList = []*prometheus.GaugeVec{
 		metricValidationFailed,
@@ -181,9 +268,33 @@ var (
 		metricUpgradeWorkerTimeout,
 		metricNodeDrainFailed,
 		metricUpgradeNotification,
+		metricPostUpgradeCriticalAlert,
+		metricValidationFailedV2,
+		metricClusterCheckFailedV2,
+		metricScalingFailedV2,
+		metricClusterVerificationFailedV2,
+		metricUpgradeWindowBreachedV2,
+		metricUpgradeConfigSyncedV2,
+		metricUpgradeControlPlaneTimeoutV2,
+		metricUpgradeWorkerTimeoutV2,
+		metricNodeDrainFailedV2,
+		metricUpgradeNotificationV2,
 	}
 )
 
+// setDual sets both a legacy GaugeVec and its NamespaceV2 counterpart to the same value, the
+// V2 series additionally labelled with this Counter's cluster_id. See NamespaceV2.
+func (c *Counter) setDual(legacy, v2 *prometheus.GaugeVec, labels prometheus.Labels, value float64) {
+	legacy.With(labels).Set(value)
+
+	v2Labels := make(prometheus.Labels, len(labels)+1)
+	for k, v := range labels {
+		v2Labels[k] = v
+	}
+	v2Labels[clusterIDLabel] = c.clusterID
+	v2.With(v2Labels).Set(value)
+}
+
 func init() {
 	for _, m := range metricsList {
 		metrics.Registry.MustRegister(m)
@@ -191,135 +302,176 @@ func init() {
 }
 
 func (c *Counter) UpdateMetricValidationFailed(upgradeConfigName string) {
-	metricValidationFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(1))
+	c.setDual(metricValidationFailed, metricValidationFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 1)
 }
 
 func (c *Counter) UpdateMetricValidationSucceeded(upgradeConfigName string) {
-	metricValidationFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricValidationFailed, metricValidationFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) UpdateMetricClusterCheckFailed(upgradeConfigName string) {
-	metricClusterCheckFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(1))
+	c.setDual(metricClusterCheckFailed, metricClusterCheckFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 1)
 }
 
 func (c *Counter) UpdateMetricClusterCheckSucceeded(upgradeConfigName string) {
-	metricClusterCheckFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricClusterCheckFailed, metricClusterCheckFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) ResetMetricClusterCheck(upgradeConfigName string) {
-	metricClusterCheckFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricClusterCheckFailed, metricClusterCheckFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) UpdateMetricScalingFailed(upgradeConfigName string) {
-	metricScalingFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(1))
+	c.setDual(metricScalingFailed, metricScalingFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 1)
 }
 
 func (c *Counter) UpdateMetricScalingSucceeded(upgradeConfigName string) {
-	metricScalingFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricScalingFailed, metricScalingFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) ResetMetricScaling(upgradeConfigName string) {
-	metricScalingFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricScalingFailed, metricScalingFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) UpdateMetricUpgradeConfigSynced(name string) {
-	metricUpgradeConfigSynced.With(prometheus.Labels{nameLabel: name}).Set(float64(1))
+	c.setDual(metricUpgradeConfigSynced, metricUpgradeConfigSyncedV2, prometheus.Labels{nameLabel: name}, 1)
 }
 
 func (c *Counter) ResetMetricUpgradeConfigSynced(name string) {
-	metricUpgradeConfigSynced.With(prometheus.Labels{nameLabel: name}).Set(float64(0))
+	c.setDual(metricUpgradeConfigSynced, metricUpgradeConfigSyncedV2, prometheus.Labels{nameLabel: name}, 0)
 }
 
 func (c *Counter) UpdateMetricUpgradeControlPlaneTimeout(upgradeConfigName, version string) {
-	metricUpgradeControlPlaneTimeout.With(prometheus.Labels{
-		VersionLabel: version,
-		nameLabel:    upgradeConfigName}).Set(
-		float64(1))
+	c.setDual(metricUpgradeControlPlaneTimeout, metricUpgradeControlPlaneTimeoutV2,
+		prometheus.Labels{VersionLabel: version, nameLabel: upgradeConfigName}, 1)
 }
 
 func (c *Counter) ResetMetricUpgradeControlPlaneTimeout(upgradeConfigName, version string) {
-	metricUpgradeControlPlaneTimeout.With(prometheus.Labels{
-		VersionLabel: version,
-		nameLabel:    upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricUpgradeControlPlaneTimeout, metricUpgradeControlPlaneTimeoutV2,
+		prometheus.Labels{VersionLabel: version, nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) UpdateMetricUpgradeWorkerTimeout(upgradeConfigName, version string) {
-	metricUpgradeWorkerTimeout.With(prometheus.Labels{
-		VersionLabel: version,
-		nameLabel:    upgradeConfigName}).Set(
-		float64(1))
+	c.setDual(metricUpgradeWorkerTimeout, metricUpgradeWorkerTimeoutV2,
+		prometheus.Labels{VersionLabel: version, nameLabel: upgradeConfigName}, 1)
 }
 
 func (c *Counter) ResetMetricUpgradeWorkerTimeout(upgradeConfigName, version string) {
-	metricUpgradeWorkerTimeout.With(prometheus.Labels{
-		VersionLabel: version,
-		nameLabel:    upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricUpgradeWorkerTimeout, metricUpgradeWorkerTimeoutV2,
+		prometheus.Labels{VersionLabel: version, nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) UpdateMetricNodeDrainFailed(nodeName string) {
-	metricNodeDrainFailed.With(prometheus.Labels{
-		nodeLabel: nodeName}).Set(
-		float64(1))
+	c.setDual(metricNodeDrainFailed, metricNodeDrainFailedV2, prometheus.Labels{nodeLabel: nodeName}, 1)
 }
 
 func (c *Counter) ResetMetricNodeDrainFailed(nodeName string) {
-	metricNodeDrainFailed.With(prometheus.Labels{
-		nodeLabel: nodeName}).Set(
-		float64(0))
+	c.setDual(metricNodeDrainFailed, metricNodeDrainFailedV2, prometheus.Labels{nodeLabel: nodeName}, 0)
 }
 
 func (c *Counter) UpdateMetricClusterVerificationFailed(upgradeConfigName string) {
-	metricClusterVerificationFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(1))
+	c.setDual(metricClusterVerificationFailed, metricClusterVerificationFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 1)
 }
 
 func (c *Counter) UpdateMetricClusterVerificationSucceeded(upgradeConfigName string) {
-	metricClusterVerificationFailed.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricClusterVerificationFailed, metricClusterVerificationFailedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) UpdateMetricUpgradeWindowNotBreached(upgradeConfigName string) {
-	metricUpgradeWindowBreached.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(0))
+	c.setDual(metricUpgradeWindowBreached, metricUpgradeWindowBreachedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 0)
 }
 
 func (c *Counter) UpdateMetricUpgradeWindowBreached(upgradeConfigName string) {
-	metricUpgradeWindowBreached.With(prometheus.Labels{
-		nameLabel: upgradeConfigName}).Set(
-		float64(1))
+	c.setDual(metricUpgradeWindowBreached, metricUpgradeWindowBreachedV2,
+		prometheus.Labels{nameLabel: upgradeConfigName}, 1)
 }
 
 func (c *Counter) UpdateMetricNotificationEventSent(upgradeConfigName string, event string, version string) {
-	metricUpgradeNotification.With(prometheus.Labels{
-		VersionLabel: version,
-		eventLabel:   event,
-		nameLabel:    upgradeConfigName}).Set(
+	c.setDual(metricUpgradeNotification, metricUpgradeNotificationV2,
+		prometheus.Labels{VersionLabel: version, eventLabel: event, nameLabel: upgradeConfigName}, 1)
+}
+
+func (c *Counter) UpdateMetricPostUpgradeCriticalAlert(upgradeConfigName, alertName string) {
+	metricPostUpgradeCriticalAlert.With(prometheus.Labels{
+		nameLabel:      upgradeConfigName,
+		alertNameLabel: alertName,
+		clusterIDLabel: c.clusterID}).Set(
 		float64(1))
 }
 
+func (c *Counter) ResetMetricPostUpgradeCriticalAlerts(upgradeConfigName string) {
+	metricPostUpgradeCriticalAlert.DeletePartialMatch(prometheus.Labels{
+		nameLabel:      upgradeConfigName,
+		clusterIDLabel: c.clusterID})
+}
+
+// FiringAlert is a single alert series returned by GetFiringCriticalAlerts.
+type FiringAlert struct {
+	Name   string
+	Labels map[string]string
+}
+
+// PostUpgradeAlertGateConfig configures the post-upgrade alert soak run by the upgrade state
+// machine after WorkersCompletedStateValue. It is intended to be set on the UpgradeConfig CR;
+// the CR type itself lives outside this package.
+//
+// Nothing in this repo slice instantiates or reads a PostUpgradeAlertGateConfig yet - there is
+// no upgrade state machine here to own the "wait SoakDuration, then call
+// GetFiringCriticalAlerts" orchestration it's meant to configure. It exists so that machine has
+// the shape to target when it lands.
+type PostUpgradeAlertGateConfig struct {
+	// SoakDuration is how long to wait after WorkersCompletedStateValue before sampling.
+	SoakDuration time.Duration
+	// FiringFor is the minimum duration an alert must have been firing within the soak window
+	// to count against the gate.
+	FiringFor time.Duration
+	// IgnoreAlerts is an allowlist of alertnames excluded from the gate.
+	IgnoreAlerts []string
+}
+
+// GetFiringCriticalAlerts returns every severity=critical alert that has been firing for at
+// least `window`, excluding any alertname in ignore. It is intended to be polled across a
+// post-upgrade soak window by the upgrade state machine before declaring an upgrade verified.
+func (c *Counter) GetFiringCriticalAlerts(window time.Duration, ignore []string) ([]FiringAlert, error) {
+	query := fmt.Sprintf(`ALERTS{alertstate="firing",severity="critical"} unless ALERTS{alertname=~"%s"}`,
+		strings.Join(ignore, "|"))
+	if window > 0 {
+		// ALERTS_FOR_STATE carries no alertstate label, so it is matched against ALERTS
+		// ignoring that label.
+		query = fmt.Sprintf(`(time() - ALERTS_FOR_STATE{severity="critical"} >= %d) and ignoring(alertstate) (%s)`,
+			int64(window.Seconds()), query)
+	}
+
+	resp, err := c.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine firing critical alerts: %w", err)
+	}
+
+	alerts := make([]FiringAlert, 0, len(resp.Data.Result))
+	for _, r := range resp.Data.Result {
+		alerts = append(alerts, FiringAlert{
+			Name:   r.Metric[alertNameLabel],
+			Labels: r.Metric,
+		})
+	}
+
+	return alerts, nil
+}
+
 func (c *Counter) IsMetricNotificationEventSentSet(upgradeConfigName string, event string, version string) (bool, error) {
-	cpMetrics, err := c.Query(fmt.Sprintf("%s_upgrade_notification{%s=\"%s\",%s=\"%s\",%s=\"%s\"}", metricsTag, nameLabel, upgradeConfigName, eventLabel, event, VersionLabel, version))
+	cpMetrics, err := c.Query(fmt.Sprintf("%s_upgrade_notification{%s=\"%s\",%s=\"%s\",%s=\"%s\",%s=\"%s\"}",
+		NamespaceV2, nameLabel, upgradeConfigName, eventLabel, event, VersionLabel, version, clusterIDLabel, c.clusterID))
 	if err != nil {
 		return false, err
 	}
@@ -358,74 +510,38 @@ func (c *Counter) IsAlertFiring(alert string, checkedNS, ignoredNS []string) (bo
 	return false, nil
 }
 
-func getPromHost(c client.Client) (*string, error) {
-	route := &routev1.Route{}
-	err := c.Get(context.TODO(), types.NamespacedName{Namespace: "openshift-monitoring", Name: "prometheus-k8s"}, route)
-	if err != nil {
-		return nil, err
-	}
-
-	return &route.Spec.Host, nil
-}
-
 func (c *Counter) Query(query string) (*AlertResponse, error) {
-	req, err := http.NewRequest("GET", "https://"+c.promHost+"/api/v1/query", nil)
+	req, err := http.NewRequest("GET", c.promHost+"/api/v1/query", nil)
 	if err != nil {
-		return nil, fmt.Errorf("Could not query Prometheus: %s", err)
+		return nil, fmt.Errorf("%w: %s", ErrPromQuery, err)
 	}
 
 	q := req.URL.Query()
 	q.Add("query", query)
+	for k, v := range c.queryParams {
+		q.Add(k, v)
+	}
 	req.URL.RawQuery = q.Encode()
 	resp, err := c.promClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %s", ErrPromQuery, err)
 	}
 
 	defer resp.Body.Close()
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("Error when querying Prometheus: %s", err)
+		return nil, fmt.Errorf("%w: %s", ErrPromDecode, err)
 	}
 
 	result := &AlertResponse{}
 	err = json.Unmarshal(body, result)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: %s", ErrPromDecode, err)
 	}
 
 	return result, nil
 }
 
-func getPrometheusToken(c client.Client) (*string, error) {
-	sa := &corev1.ServiceAccount{}
-	err := c.Get(context.TODO(), types.NamespacedName{Namespace: "openshift-monitoring", Name: "prometheus-k8s"}, sa)
-	if err != nil {
-		return nil, fmt.Errorf("Unable to fetch prometheus-k8s service account: %s", err)
-	}
-
-	tokenSecret := ""
-	for _, secret := range sa.Secrets {
-		if strings.HasPrefix(secret.Name, "prometheus-k8s-token") {
-			tokenSecret = secret.Name
-		}
-	}
-	if len(tokenSecret) == 0 {
-		return nil, fmt.Errorf("Failed to find token secret for prommetheus-k8s SA")
-	}
-
-	secret := &corev1.Secret{}
-	err = c.Get(context.TODO(), types.NamespacedName{Namespace: "openshift-monitoring", Name: tokenSecret}, secret)
-	if err != nil {
-		return nil, fmt.Errorf("Unable to fetch secret %s: %s", tokenSecret, err)
-	}
-
-	token := secret.Data[corev1.ServiceAccountTokenKey]
-	stringToken := string(token)
-
-	return &stringToken, nil
-}
-
 type AlertResponse struct {
 	Status string    `json:"status"`
 	Data   AlertData `json:"data"`
@@ -0,0 +1,202 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	routev1 "github.com/openshift/api/route/v1"
+)
+
+const (
+	monitoringNamespace = "openshift-monitoring"
+	// operatorNamespace is where the operator itself (and the ServiceAccount it requests
+	// tokens for) runs.
+	operatorNamespace = "openshift-managed-upgrade-operator"
+	// defaultOperatorServiceAccount is the operator's own ServiceAccount. A token is requested
+	// for this identity with promTokenAudience, not for prometheus-k8s's own ServiceAccount -
+	// the operator has no business minting tokens for another component's identity, and
+	// kube-rbac-proxy in front of Prometheus validates the request by audience, not subject.
+	defaultOperatorServiceAccount = "managed-upgrade-operator"
+	promTokenAudience             = "prometheus-k8s"
+	servingCAConfigMapName        = "openshift-service-ca.crt"
+	servingCAConfigMapKey         = "service-ca.crt"
+	promTokenExpirationSecs       = int64(3600)
+	// tokenRefreshSkew is how long before a TokenRequest token's expiry it gets refreshed.
+	tokenRefreshSkew = 2 * time.Minute
+)
+
+// newOCPClient builds a Counter that discovers and queries the in-cluster OpenShift
+// monitoring Prometheus via the thanos-querier/prometheus-k8s Service (or, if cfg.UseRoute is
+// set, the Route), authenticating with a short-lived, audience-scoped TokenRequest token
+// issued for the operator's own ServiceAccount, and trusting the cluster's own serving CA.
+func newOCPClient(c client.Client, cfg OCPBackendConfig, clusterID string) (Metrics, error) {
+	host, err := getPromHost(c, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	caPool, err := getServingCAPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	saName := cfg.ServiceAccountName
+	if saName == "" {
+		saName = defaultOperatorServiceAccount
+	}
+
+	clientset, err := newInClusterClientset()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Counter{
+		promHost: "https://" + *host,
+		promClient: http.Client{
+			Transport: &prometheusRoundTripper{
+				clientset: clientset,
+				saName:    saName,
+				caPool:    caPool,
+			},
+		},
+		clusterID: clusterID,
+	}, nil
+}
+
+func newInClusterClientset() (kubernetes.Interface, error) {
+	restCfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%w: could not load in-cluster config: %s", ErrPromTokenNotFound, err)
+	}
+	return kubernetes.NewForConfig(restCfg)
+}
+
+// prometheusRoundTripper authenticates requests with a TokenRequest-issued token, refreshing
+// it shortly before expiry, and trusts whatever serving CA pool it currently holds. caPool can
+// be swapped out via reload when the openshift-service-ca.crt ConfigMap rotates.
+type prometheusRoundTripper struct {
+	clientset kubernetes.Interface
+	saName    string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	caPool    *x509.CertPool
+}
+
+func (prt *prometheusRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := prt.currentToken()
+	if err != nil {
+		return nil, fmt.Errorf("could not obtain prometheus token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	prt.mu.Lock()
+	caPool := prt.caPool
+	prt.mu.Unlock()
+
+	transport := &http.Transport{
+		TLSHandshakeTimeout: 5 * time.Second,
+		TLSClientConfig:     &tls.Config{RootCAs: caPool},
+	}
+	return transport.RoundTrip(req)
+}
+
+func (prt *prometheusRoundTripper) currentToken() (string, error) {
+	prt.mu.Lock()
+	defer prt.mu.Unlock()
+
+	if prt.token != "" && time.Now().Before(prt.expiresAt.Add(-tokenRefreshSkew)) {
+		return prt.token, nil
+	}
+
+	expirationSeconds := promTokenExpirationSecs
+	tr, err := prt.clientset.CoreV1().ServiceAccounts(operatorNamespace).CreateToken(
+		context.TODO(),
+		prt.saName,
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				Audiences:         []string{promTokenAudience},
+				ExpirationSeconds: &expirationSeconds,
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrPromTokenNotFound, err)
+	}
+
+	prt.token = tr.Status.Token
+	prt.expiresAt = tr.Status.ExpirationTimestamp.Time
+	return prt.token, nil
+}
+
+// reload swaps in a freshly-fetched serving CA pool, so an in-flight Counter doesn't need to be
+// rebuilt from scratch when the source ConfigMap rotates. It is reached through
+// Metrics.ReloadCA; wiring an actual watch on openshift-service-ca.crt that calls ReloadCA
+// belongs to the controller manager setup, which is outside this package.
+func (prt *prometheusRoundTripper) reload(c client.Client) error {
+	pool, err := getServingCAPool(c)
+	if err != nil {
+		return err
+	}
+
+	prt.mu.Lock()
+	prt.caPool = pool
+	prt.mu.Unlock()
+	return nil
+}
+
+func getPromHost(c client.Client, cfg OCPBackendConfig) (*string, error) {
+	if cfg.UseRoute {
+		route := &routev1.Route{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Namespace: monitoringNamespace, Name: "prometheus-k8s"}, route); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrPromRouteNotFound, err)
+		}
+		return &route.Spec.Host, nil
+	}
+
+	svc := &corev1.Service{}
+	err := c.Get(context.TODO(), types.NamespacedName{Namespace: monitoringNamespace, Name: "thanos-querier"}, svc)
+	if err != nil {
+		err = c.Get(context.TODO(), types.NamespacedName{Namespace: monitoringNamespace, Name: "prometheus-k8s"}, svc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrPromRouteNotFound, err)
+		}
+	}
+
+	host := fmt.Sprintf("%s.%s.svc:9091", svc.Name, svc.Namespace)
+	return &host, nil
+}
+
+func getServingCAPool(c client.Client) (*x509.CertPool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Namespace: monitoringNamespace, Name: servingCAConfigMapName}, cm); err != nil {
+		return nil, fmt.Errorf("%w: could not fetch serving CA configmap %s: %s", ErrServingCANotFound, servingCAConfigMapName, err)
+	}
+
+	pem, ok := cm.Data[servingCAConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: serving CA configmap %s has no %s key", ErrServingCANotFound, servingCAConfigMapName, servingCAConfigMapKey)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(pem)) {
+		return nil, fmt.Errorf("%w: no certificates found in serving CA configmap %s", ErrServingCANotFound, servingCAConfigMapName)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// newThanosClient builds a Counter that queries a user-supplied Thanos/Prometheus endpoint
+// directly, as opposed to discovering the in-cluster OpenShift monitoring Prometheus.
+func newThanosClient(cfg ThanosBackendConfig, clusterID string) (Metrics, error) {
+	tlsConfig := &tls.Config{}
+
+	if cfg.CAFile != "" {
+		caCert, err := ioutil.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read thanos CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificates found in thanos CA file %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.ClientCertFile != "" && cfg.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.ClientCertFile, cfg.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load thanos client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := &thanosRoundTripper{
+		bearerTokenFile: cfg.BearerTokenFile,
+		transport: &http.Transport{
+			TLSHandshakeTimeout: time.Second * 5,
+			TLSClientConfig:     tlsConfig,
+		},
+	}
+
+	queryParams := map[string]string{}
+	if cfg.PartialResponse {
+		queryParams["partial_response"] = strconv.FormatBool(true)
+	}
+	if cfg.Dedup {
+		queryParams["dedup"] = strconv.FormatBool(true)
+	}
+
+	return &Counter{
+		promHost:    strings.TrimSuffix(cfg.URL, "/"),
+		promClient:  http.Client{Transport: transport},
+		queryParams: queryParams,
+		clusterID:   clusterID,
+	}, nil
+}
+
+type thanosRoundTripper struct {
+	bearerTokenFile string
+	transport       http.RoundTripper
+}
+
+func (trt *thanosRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if trt.bearerTokenFile != "" {
+		token, err := ioutil.ReadFile(trt.bearerTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read thanos bearer token file: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+	return trt.transport.RoundTrip(req)
+}